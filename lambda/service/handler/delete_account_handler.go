@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/pennsieve/account-service/service/logging"
+	"github.com/pennsieve/account-service/service/store_dynamodb"
+	"github.com/pennsieve/account-service/service/utils"
+	"github.com/pennsieve/pennsieve-go-core/pkg/authorizer"
+)
+
+func DeleteAccountHandler(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	handlerName := "DeleteAccountHandler"
+	uuid := request.PathParameters["id"]
+
+	claims := authorizer.ParseClaims(request.RequestContext.Authorizer.Lambda)
+	userId := claims.UserClaim.NodeId
+	handlerLogger := logging.NewHandlerLogger(ctx, handlerName).With(
+		slog.String("uuid", uuid),
+		slog.String("userId", userId))
+
+	dynamoDBClient, err := utils.NewDynamoClient(ctx)
+	if err != nil {
+		handlerLogger.Error("error creating DynamoDB client", slog.Any("error", err))
+		return events.APIGatewayV2HTTPResponse{
+			StatusCode: http.StatusInternalServerError,
+			Body:       handlerError(ctx, handlerName, ErrConfig),
+		}, nil
+	}
+	accountsTable := os.Getenv("ACCOUNTS_TABLE")
+	accountsStore := store_dynamodb.NewAccountDatabaseStore(dynamoDBClient, accountsTable)
+
+	account, err := accountsStore.GetById(ctx, uuid)
+	if err != nil {
+		handlerLogger.Error("error getting account", slog.Any("error", err))
+		return events.APIGatewayV2HTTPResponse{
+			StatusCode: http.StatusInternalServerError,
+			Body:       handlerError(ctx, handlerName, ErrDynamoDB),
+		}, nil
+	}
+
+	if (store_dynamodb.Account{}) == account {
+		return events.APIGatewayV2HTTPResponse{
+			StatusCode: http.StatusNotFound,
+			Body:       handlerError(ctx, handlerName, ErrNoRecordsFound),
+		}, nil
+	}
+
+	// only the user who registered the account may delete it
+	if account.UserId != userId {
+		handlerLogger.Warn("caller does not own account", slog.String("ownerId", account.UserId))
+		return events.APIGatewayV2HTTPResponse{
+			StatusCode: http.StatusForbidden,
+			Body:       handlerError(ctx, handlerName, ErrForbidden),
+		}, nil
+	}
+
+	// refuse to delete an account that compute nodes still depend on
+	nodesTable := os.Getenv("COMPUTE_NODES_TABLE")
+	if nodesTable == "" {
+		handlerLogger.Error("COMPUTE_NODES_TABLE is not set; cannot verify account has no dependent nodes")
+		return events.APIGatewayV2HTTPResponse{
+			StatusCode: http.StatusInternalServerError,
+			Body:       handlerError(ctx, handlerName, ErrConfig),
+		}, nil
+	}
+	nodesStore := store_dynamodb.NewComputeNodeDatabaseStore(dynamoDBClient, nodesTable)
+	hasNodes, err := nodesStore.HasNodesForAccount(ctx, uuid)
+	if err != nil {
+		handlerLogger.Error("error checking for dependent compute nodes", slog.Any("error", err))
+		return events.APIGatewayV2HTTPResponse{
+			StatusCode: http.StatusInternalServerError,
+			Body:       handlerError(ctx, handlerName, ErrDynamoDB),
+		}, nil
+	}
+	if hasNodes {
+		return events.APIGatewayV2HTTPResponse{
+			StatusCode: http.StatusConflict,
+			Body:       handlerError(ctx, handlerName, ErrAccountHasNodes),
+		}, nil
+	}
+
+	err = accountsStore.Delete(ctx, uuid)
+	if err != nil {
+		handlerLogger.Error("error deleting account", slog.Any("error", err))
+		return events.APIGatewayV2HTTPResponse{
+			StatusCode: http.StatusInternalServerError,
+			Body:       handlerError(ctx, handlerName, ErrDynamoDB),
+		}, nil
+	}
+
+	return events.APIGatewayV2HTTPResponse{
+		StatusCode: http.StatusNoContent,
+	}, nil
+}
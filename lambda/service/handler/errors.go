@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+
+	"github.com/pennsieve/account-service/service/logging"
 )
 
 var ErrUnsupportedRoute = errors.New("unsupported route")
@@ -15,7 +19,61 @@ var ErrUnmarshaling = errors.New("error unmarshaling body")
 var ErrDynamoDB = errors.New("error performing action on DynamoDB table")
 var ErrNoRecordsFound = errors.New("error no records found")
 var ErrRecordAlreadyExists = errors.New("error records exists")
+var ErrForbidden = errors.New("error forbidden")
+var ErrAccountHasNodes = errors.New("error account is still referenced by compute nodes")
+var ErrPayloadTooLarge = errors.New("error request body too large")
+var ErrMissingRequiredField = errors.New("error missing required field")
+
+// APIError is the JSON body returned with every error response
+type APIError struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestId string `json:"requestId,omitempty"`
+}
+
+// errorCodes maps sentinel errors to stable, machine-readable codes.
+// It is ordered so an error wrapping several sentinels always resolves to the first match.
+var errorCodes = []struct {
+	sentinel error
+	code     string
+}{
+	{ErrUnsupportedRoute, "ROUTE_NOT_FOUND"},
+	{ErrUnsupportedPath, "UNSUPPORTED_METHOD"},
+	{ErrUnsupportedAccountType, "UNSUPPORTED_ACCOUNT_TYPE"},
+	{ErrMarshaling, "MARSHALING_ERROR"},
+	{ErrConfig, "CONFIG_ERROR"},
+	{ErrSTS, "STS_ERROR"},
+	{ErrUnmarshaling, "INVALID_REQUEST_BODY"},
+	{ErrDynamoDB, "DATABASE_ERROR"},
+	{ErrNoRecordsFound, "ACCOUNT_NOT_FOUND"},
+	{ErrRecordAlreadyExists, "ACCOUNT_ALREADY_EXISTS"},
+	{ErrForbidden, "FORBIDDEN"},
+	{ErrAccountHasNodes, "ACCOUNT_HAS_NODES"},
+	{ErrPayloadTooLarge, "PAYLOAD_TOO_LARGE"},
+	{ErrMissingRequiredField, "MISSING_REQUIRED_FIELD"},
+}
+
+func errorCode(err error) string {
+	for _, c := range errorCodes {
+		if errors.Is(err, c.sentinel) {
+			return c.code
+		}
+	}
+	return "INTERNAL_ERROR"
+}
+
+func apiErrorBody(ctx context.Context, message string, err error) string {
+	m, marshalErr := json.Marshal(APIError{
+		Code:      errorCode(err),
+		Message:   message,
+		RequestId: logging.RequestId(ctx),
+	})
+	if marshalErr != nil {
+		return message
+	}
+	return string(m)
+}
 
-func handlerError(handlerName string, handlerError error) string {
-	return fmt.Sprintf("%s: %s", handlerName, handlerError.Error())
+func handlerError(ctx context.Context, handlerName string, handlerError error) string {
+	return apiErrorBody(ctx, fmt.Sprintf("%s: %s", handlerName, handlerError.Error()), handlerError)
 }
@@ -3,63 +3,57 @@ package handler
 import (
 	"context"
 	"encoding/json"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 
 	"github.com/aws/aws-lambda-go/events"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
-	"github.com/pennsieve/account-service/service/models"
+	"github.com/pennsieve/account-service/service/logging"
+	"github.com/pennsieve/account-service/service/mappers"
 	"github.com/pennsieve/account-service/service/store_dynamodb"
+	"github.com/pennsieve/account-service/service/utils"
+	"github.com/pennsieve/pennsieve-go-core/pkg/authorizer"
 )
 
 func GetAccountHandler(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
 	handlerName := "GetAccountHandler"
 	uuid := request.PathParameters["id"]
+	claims := authorizer.ParseClaims(request.RequestContext.Authorizer.Lambda)
+	handlerLogger := logging.NewHandlerLogger(ctx, handlerName).With(slog.String("uuid", uuid))
 
-	cfg, err := config.LoadDefaultConfig(ctx)
+	dynamoDBClient, err := utils.NewDynamoClient(ctx)
 	if err != nil {
-		log.Println(err.Error())
+		handlerLogger.Error("error creating DynamoDB client", slog.Any("error", err))
 		return events.APIGatewayV2HTTPResponse{
 			StatusCode: http.StatusInternalServerError,
-			Body:       handlerError(handlerName, ErrConfig),
+			Body:       handlerError(ctx, handlerName, ErrConfig),
 		}, nil
 	}
-	dynamoDBClient := dynamodb.NewFromConfig(cfg)
 	accountsTable := os.Getenv("ACCOUNTS_TABLE")
 
 	dynamo_store := store_dynamodb.NewAccountDatabaseStore(dynamoDBClient, accountsTable)
 	account, err := dynamo_store.GetById(ctx, uuid)
 	if err != nil {
-		log.Println(err.Error())
+		handlerLogger.Error("error getting account", slog.Any("error", err))
 		return events.APIGatewayV2HTTPResponse{
 			StatusCode: http.StatusInternalServerError,
-			Body:       handlerError(handlerName, ErrDynamoDB),
+			Body:       handlerError(ctx, handlerName, ErrDynamoDB),
 		}, nil
 	}
 
 	if (store_dynamodb.Account{}) == account {
 		return events.APIGatewayV2HTTPResponse{
 			StatusCode: http.StatusNotFound,
-			Body:       handlerError(handlerName, ErrNoRecordsFound),
+			Body:       handlerError(ctx, handlerName, ErrNoRecordsFound),
 		}, nil
 	}
 
-	m, err := json.Marshal(models.Account{
-		Uuid:           account.Uuid,
-		AccountId:      account.AccountId,
-		AccountType:    account.AccountType,
-		RoleName:       account.RoleName,
-		ExternalId:     account.ExternalId,
-		OrganizationId: account.OrganizationId,
-		UserId:         account.UserId,
-	})
+	m, err := json.Marshal(mappers.DynamoDBAccountToAccountWithOwnership(account, claims.UserClaim.NodeId))
 	if err != nil {
-		log.Println(err.Error())
+		handlerLogger.Error("error marshaling account", slog.Any("error", err))
 		return events.APIGatewayV2HTTPResponse{
 			StatusCode: http.StatusInternalServerError,
-			Body:       handlerError(handlerName, ErrMarshaling),
+			Body:       handlerError(ctx, handlerName, ErrMarshaling),
 		}, nil
 	}
 	response := events.APIGatewayV2HTTPResponse{
@@ -3,52 +3,82 @@ package handler
 import (
 	"context"
 	"encoding/json"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 
 	"github.com/aws/aws-lambda-go/events"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/pennsieve/account-service/service/logging"
 	"github.com/pennsieve/account-service/service/mappers"
 	"github.com/pennsieve/account-service/service/store_dynamodb"
+	"github.com/pennsieve/account-service/service/utils"
 	"github.com/pennsieve/pennsieve-go-core/pkg/authorizer"
 )
 
 func GetAccountsHandler(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
 	handlerName := "GetAccountsHandler"
 	queryParams := request.QueryStringParameters
+	handlerLogger := logging.NewHandlerLogger(ctx, handlerName)
 
-	cfg, err := config.LoadDefaultConfig(ctx)
+	dynamoDBClient, err := utils.NewDynamoClient(ctx)
 	if err != nil {
-		log.Println(err.Error())
+		handlerLogger.Error("error creating DynamoDB client", slog.Any("error", err))
 		return events.APIGatewayV2HTTPResponse{
 			StatusCode: http.StatusInternalServerError,
-			Body:       handlerError(handlerName, ErrConfig),
+			Body:       handlerError(ctx, handlerName, ErrConfig),
 		}, nil
 	}
-	dynamoDBClient := dynamodb.NewFromConfig(cfg)
 	accountsTable := os.Getenv("ACCOUNTS_TABLE")
 
 	claims := authorizer.ParseClaims(request.RequestContext.Authorizer.Lambda)
 	organizationId := claims.OrgClaim.NodeId
+	userId := claims.UserClaim.NodeId
+	handlerLogger = handlerLogger.With(
+		slog.String("organizationId", organizationId),
+		slog.String("userId", userId))
 
 	dynamo_store := store_dynamodb.NewAccountDatabaseStore(dynamoDBClient, accountsTable)
+	if accountId, found := queryParams["accountId"]; found {
+		return getAccountsByAccountId(ctx, dynamo_store, accountId, claims, handlerName, handlerLogger)
+	}
+
+	// accounts registered in the caller's current workspace
 	dynamoAccounts, err := dynamo_store.Get(ctx, organizationId, queryParams)
 	if err != nil {
-		log.Println(err.Error())
+		handlerLogger.Error("error getting accounts", slog.Any("error", err))
+		return events.APIGatewayV2HTTPResponse{
+			StatusCode: http.StatusInternalServerError,
+			Body:       handlerError(ctx, handlerName, ErrDynamoDB),
+		}, nil
+	}
+
+	// accounts the caller registered in any workspace
+	userAccounts, err := dynamo_store.GetByUser(ctx, userId)
+	if err != nil {
+		handlerLogger.Error("error getting accounts by userId", slog.Any("error", err))
 		return events.APIGatewayV2HTTPResponse{
 			StatusCode: http.StatusInternalServerError,
-			Body:       handlerError(handlerName, ErrDynamoDB),
+			Body:       handlerError(ctx, handlerName, ErrDynamoDB),
 		}, nil
 	}
 
-	m, err := json.Marshal(mappers.DynamoDBAccountToJsonAccount(dynamoAccounts))
+	seen := make(map[string]bool)
+	for _, a := range dynamoAccounts {
+		seen[a.Uuid] = true
+	}
+	for _, a := range userAccounts {
+		if !seen[a.Uuid] {
+			seen[a.Uuid] = true
+			dynamoAccounts = append(dynamoAccounts, a)
+		}
+	}
+
+	m, err := json.Marshal(mappers.DynamoDBAccountToJsonAccount(dynamoAccounts, userId))
 	if err != nil {
-		log.Println(err.Error())
+		handlerLogger.Error("error marshaling accounts", slog.Any("error", err))
 		return events.APIGatewayV2HTTPResponse{
 			StatusCode: http.StatusInternalServerError,
-			Body:       handlerError(handlerName, ErrMarshaling),
+			Body:       handlerError(ctx, handlerName, ErrMarshaling),
 		}, nil
 	}
 	response := events.APIGatewayV2HTTPResponse{
@@ -57,3 +87,48 @@ func GetAccountsHandler(ctx context.Context, request events.APIGatewayV2HTTPRequ
 	}
 	return response, nil
 }
+
+// getAccountsByAccountId looks up registrations of a cloud account id across organizations.
+// Registrations in the caller's current organization or owned by the caller are returned;
+// super admins see every registration.
+func getAccountsByAccountId(ctx context.Context, store store_dynamodb.DynamoDBStore, accountId string,
+	claims *authorizer.Claims, handlerName string, handlerLogger *slog.Logger) (events.APIGatewayV2HTTPResponse, error) {
+	handlerLogger = handlerLogger.With(slog.String("accountId", accountId))
+
+	dynamoAccounts, err := store.GetByAccountId(ctx, accountId)
+	if err != nil {
+		handlerLogger.Error("error getting accounts by accountId", slog.Any("error", err))
+		return events.APIGatewayV2HTTPResponse{
+			StatusCode: http.StatusInternalServerError,
+			Body:       handlerError(ctx, handlerName, ErrDynamoDB),
+		}, nil
+	}
+
+	visibleAccounts := []store_dynamodb.Account{}
+	for _, a := range dynamoAccounts {
+		if claims.UserClaim.IsSuperAdmin ||
+			a.UserId == claims.UserClaim.NodeId ||
+			a.OrganizationId == claims.OrgClaim.NodeId {
+			visibleAccounts = append(visibleAccounts, a)
+		}
+	}
+	if len(visibleAccounts) == 0 {
+		return events.APIGatewayV2HTTPResponse{
+			StatusCode: http.StatusNotFound,
+			Body:       handlerError(ctx, handlerName, ErrNoRecordsFound),
+		}, nil
+	}
+
+	m, err := json.Marshal(mappers.DynamoDBAccountToJsonAccount(visibleAccounts, claims.UserClaim.NodeId))
+	if err != nil {
+		handlerLogger.Error("error marshaling accounts", slog.Any("error", err))
+		return events.APIGatewayV2HTTPResponse{
+			StatusCode: http.StatusInternalServerError,
+			Body:       handlerError(ctx, handlerName, ErrMarshaling),
+		}, nil
+	}
+	return events.APIGatewayV2HTTPResponse{
+		StatusCode: http.StatusOK,
+		Body:       string(m),
+	}, nil
+}
@@ -3,13 +3,14 @@ package handler
 import (
 	"context"
 	"encoding/json"
-	"log"
+	"log/slog"
 	"net/http"
 	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/pennsieve/account-service/service/logging"
 	"github.com/pennsieve/account-service/service/models"
 )
 
@@ -20,16 +21,17 @@ const (
 func GetPennsieveAccountsHandler(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
 	handlerName := "GetPennsieveAccountsHandler"
 	accountType := request.PathParameters["accountType"]
-	log.Println("request.RequestContext.AccountID", request.RequestContext.AccountID)
+	handlerLogger := logging.NewHandlerLogger(ctx, handlerName).With(slog.String("accountType", accountType))
+	handlerLogger.Debug("request account", slog.String("accountId", request.RequestContext.AccountID))
 
 	switch strings.ToLower(accountType) {
 	case AWS:
 		cfg, err := config.LoadDefaultConfig(ctx)
 		if err != nil {
-			log.Println(err.Error())
+			handlerLogger.Error("error loading AWS config", slog.Any("error", err))
 			return events.APIGatewayV2HTTPResponse{
 				StatusCode: http.StatusInternalServerError,
-				Body:       handlerError(handlerName, ErrConfig),
+				Body:       handlerError(ctx, handlerName, ErrConfig),
 			}, nil
 		}
 
@@ -38,10 +40,10 @@ func GetPennsieveAccountsHandler(ctx context.Context, request events.APIGatewayV
 
 		req, err := client.GetCallerIdentity(ctx, input)
 		if err != nil {
-			log.Println(err.Error())
+			handlerLogger.Error("error getting caller identity", slog.Any("error", err))
 			return events.APIGatewayV2HTTPResponse{
 				StatusCode: http.StatusInternalServerError,
-				Body:       handlerError(handlerName, ErrSTS),
+				Body:       handlerError(ctx, handlerName, ErrSTS),
 			}, nil
 		}
 		accountId := *req.Account
@@ -50,10 +52,10 @@ func GetPennsieveAccountsHandler(ctx context.Context, request events.APIGatewayV
 			Type:      AWS,
 		})
 		if err != nil {
-			log.Println(err.Error())
+			handlerLogger.Error("error marshaling pennsieve account", slog.Any("error", err))
 			return events.APIGatewayV2HTTPResponse{
 				StatusCode: http.StatusInternalServerError,
-				Body:       handlerError(handlerName, ErrMarshaling),
+				Body:       handlerError(ctx, handlerName, ErrMarshaling),
 			}, nil
 		}
 		response := events.APIGatewayV2HTTPResponse{
@@ -62,10 +64,10 @@ func GetPennsieveAccountsHandler(ctx context.Context, request events.APIGatewayV
 		}
 		return response, nil
 	default:
-		log.Println(ErrUnsupportedAccountType.Error())
+		handlerLogger.Error(ErrUnsupportedAccountType.Error())
 		return events.APIGatewayV2HTTPResponse{
 			StatusCode: http.StatusUnprocessableEntity,
-			Body:       handlerError(handlerName, ErrUnsupportedAccountType),
+			Body:       handlerError(ctx, handlerName, ErrUnsupportedAccountType),
 		}, nil
 	}
 }
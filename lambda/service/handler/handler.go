@@ -2,23 +2,17 @@ package handler
 
 import (
 	"context"
-	"log/slog"
 
 	"github.com/aws/aws-lambda-go/events"
-	"github.com/aws/aws-lambda-go/lambdacontext"
 	"github.com/pennsieve/account-service/service/logging"
 )
 
-var logger = logging.Default
-
 func init() {
-	logger.Info("init()")
+	logging.Default.Info("init()")
 }
 
 func AccountServiceHandler(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
-	if lc, ok := lambdacontext.FromContext(ctx); ok {
-		logger = logger.With(slog.String("requestID", lc.AwsRequestID))
-	}
+	ctx = logging.WithRequestId(ctx, request.RequestContext.RequestID)
 
 	router := NewLambdaRouter()
 	// register routes based on their supported methods
@@ -26,5 +20,6 @@ func AccountServiceHandler(ctx context.Context, request events.APIGatewayV2HTTPR
 	router.POST("/accounts", PostAccountsHandler)
 	router.GET("/accounts", GetAccountsHandler)
 	router.GET("/accounts/{id}", GetAccountHandler)
+	router.DELETE("/accounts/{id}", DeleteAccountHandler)
 	return router.Start(ctx, request)
 }
@@ -2,11 +2,21 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"testing"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/uuid"
+	"github.com/pennsieve/account-service/service/models"
+	"github.com/pennsieve/account-service/service/store_dynamodb"
+	"github.com/pennsieve/account-service/service/testutils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHandler(t *testing.T) {
@@ -24,7 +34,12 @@ func TestHandler(t *testing.T) {
 	}
 	resp, _ := AccountServiceHandler(context.Background(), request)
 	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
-	assert.Equal(t, ErrUnsupportedRoute.Error(), resp.Body)
+
+	var apiError APIError
+	assert.NoError(t, json.Unmarshal([]byte(resp.Body), &apiError))
+	assert.Equal(t, "ROUTE_NOT_FOUND", apiError.Code)
+	assert.Equal(t, ErrUnsupportedRoute.Error(), apiError.Message)
+	assert.Equal(t, "handler-test", apiError.RequestId)
 }
 
 func TestGetPennsieveAccountsHandler(t *testing.T) {
@@ -42,7 +57,11 @@ func TestGetPennsieveAccountsHandler(t *testing.T) {
 	}
 	resp, _ := AccountServiceHandler(context.Background(), request)
 	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
-	assert.Equal(t, "GetPennsieveAccountsHandler: unsupported account type", resp.Body)
+
+	var apiError APIError
+	assert.NoError(t, json.Unmarshal([]byte(resp.Body), &apiError))
+	assert.Equal(t, "UNSUPPORTED_ACCOUNT_TYPE", apiError.Code)
+	assert.Equal(t, "GetPennsieveAccountsHandler: unsupported account type", apiError.Message)
 }
 
 func TestPostAccountsHandler(t *testing.T) {
@@ -111,3 +130,284 @@ func TestGetAccountsHandler(t *testing.T) {
 		t.Errorf("expected status code %v, got %v", expectedStatusCode, response.StatusCode)
 	}
 }
+
+func TestHandlerErrorUnknownError(t *testing.T) {
+	var apiError APIError
+	body := handlerError(context.Background(), "SomeHandler", errors.New("some unexpected error"))
+	assert.NoError(t, json.Unmarshal([]byte(body), &apiError))
+	assert.Equal(t, "INTERNAL_ERROR", apiError.Code)
+	assert.Equal(t, "SomeHandler: some unexpected error", apiError.Message)
+}
+
+func TestPostAccountsHandlerMissingRequiredField(t *testing.T) {
+	requestContext := events.APIGatewayV2HTTPRequestContext{
+		HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+			Method: "POST",
+		},
+		Authorizer: &events.APIGatewayV2HTTPRequestContextAuthorizerDescription{
+			Lambda: make(map[string]interface{}),
+		},
+	}
+	request := events.APIGatewayV2HTTPRequest{
+		RouteKey:       "POST /accounts",
+		Body:           "{ \"accountId\": \"977668899\", \"roleName\": \"SomeRole\", \"externalId\": \"SomeExternalId\"}",
+		RequestContext: requestContext,
+	}
+
+	response, _ := AccountServiceHandler(context.Background(), request)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	var apiError APIError
+	assert.NoError(t, json.Unmarshal([]byte(response.Body), &apiError))
+	assert.Equal(t, "MISSING_REQUIRED_FIELD", apiError.Code)
+	assert.Contains(t, apiError.Message, "accountType")
+}
+
+func TestPostAccountsHandlerPayloadTooLarge(t *testing.T) {
+	t.Setenv("MAX_BODY_SIZE", "16")
+	requestContext := events.APIGatewayV2HTTPRequestContext{
+		HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+			Method: "POST",
+		},
+		Authorizer: &events.APIGatewayV2HTTPRequestContextAuthorizerDescription{
+			Lambda: make(map[string]interface{}),
+		},
+	}
+	request := events.APIGatewayV2HTTPRequest{
+		RouteKey:       "POST /accounts",
+		Body:           "{ \"accountId\": \"977668899\", \"accountType\": \"aws\"}",
+		RequestContext: requestContext,
+	}
+
+	response, _ := AccountServiceHandler(context.Background(), request)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, response.StatusCode)
+
+	var apiError APIError
+	assert.NoError(t, json.Unmarshal([]byte(response.Body), &apiError))
+	assert.Equal(t, "PAYLOAD_TOO_LARGE", apiError.Code)
+}
+
+func TestErrorCodeMultipleSentinels(t *testing.T) {
+	err := errors.Join(ErrDynamoDB, ErrNoRecordsFound)
+	assert.Equal(t, "DATABASE_ERROR", errorCode(err))
+}
+
+// setupAccountTables points the handlers at DynamoDB-local and creates empty accounts and compute nodes tables
+func setupAccountTables(t *testing.T) *dynamodb.Client {
+	client := testutils.GetClient(t)
+	t.Setenv("ACCOUNTS_TABLE", "handler-accounts")
+	t.Setenv("COMPUTE_NODES_TABLE", "handler-compute-nodes")
+
+	_, err := testutils.CreateAccountsTable(client, "handler-accounts")
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, testutils.DeleteTable(client, "handler-accounts")) })
+
+	_, err = testutils.CreateComputeNodesTable(client, "handler-compute-nodes")
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, testutils.DeleteTable(client, "handler-compute-nodes")) })
+
+	return client
+}
+
+func insertAccount(t *testing.T, client *dynamodb.Client, userId string) string {
+	return insertAccountInOrganization(t, client, userId, "N:organization:1", "123456789012")
+}
+
+func insertAccountInOrganization(t *testing.T, client *dynamodb.Client, userId string, organizationId string, accountId string) string {
+	accountUuid := uuid.New().String()
+	err := store_dynamodb.NewAccountDatabaseStore(client, "handler-accounts").Insert(context.Background(),
+		store_dynamodb.Account{
+			Uuid:           accountUuid,
+			UserId:         userId,
+			OrganizationId: organizationId,
+			AccountId:      accountId,
+			AccountType:    "aws",
+			RoleName:       "SomeRoleName",
+			ExternalId:     "SomeExternalId",
+		})
+	require.NoError(t, err)
+	return accountUuid
+}
+
+// callerClaims builds authorizer claims for a caller in the given organization
+func callerClaims(userId string, organizationId string, isSuperAdmin bool) map[string]interface{} {
+	return map[string]interface{}{
+		"user_claim": map[string]interface{}{
+			"Id":           float64(1),
+			"NodeId":       userId,
+			"IsSuperAdmin": isSuperAdmin,
+		},
+		"org_claim": map[string]interface{}{
+			"Role":   float64(16),
+			"IntId":  float64(1),
+			"NodeId": organizationId,
+		},
+	}
+}
+
+func getAccountsRequest(queryParams map[string]string, claims map[string]interface{}) events.APIGatewayV2HTTPRequest {
+	return events.APIGatewayV2HTTPRequest{
+		RouteKey:              "GET /accounts",
+		QueryStringParameters: queryParams,
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+				Method: "GET",
+			},
+			Authorizer: &events.APIGatewayV2HTTPRequestContextAuthorizerDescription{
+				Lambda: claims,
+			},
+		},
+	}
+}
+
+// accountUuids returns the uuids in a GET /accounts response body
+func accountUuids(t *testing.T, body string) []string {
+	var accounts []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(body), &accounts))
+	uuids := []string{}
+	for _, a := range accounts {
+		uuids = append(uuids, a["uuid"].(string))
+	}
+	return uuids
+}
+
+func deleteAccountRequest(accountUuid string, userId string) events.APIGatewayV2HTTPRequest {
+	return events.APIGatewayV2HTTPRequest{
+		RouteKey:       "DELETE /accounts/{id}",
+		PathParameters: map[string]string{"id": accountUuid},
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+				Method: "DELETE",
+			},
+			Authorizer: &events.APIGatewayV2HTTPRequestContextAuthorizerDescription{
+				Lambda: map[string]interface{}{
+					"user_claim": map[string]interface{}{
+						"Id":           float64(1),
+						"NodeId":       userId,
+						"IsSuperAdmin": false,
+					},
+				},
+			},
+		},
+	}
+}
+
+func assertAccountExists(t *testing.T, client *dynamodb.Client, accountUuid string, expected bool) {
+	account, err := store_dynamodb.NewAccountDatabaseStore(client, "handler-accounts").GetById(context.Background(), accountUuid)
+	require.NoError(t, err)
+	assert.Equal(t, expected, account.Uuid == accountUuid)
+}
+
+func TestDeleteAccountHandlerNotFound(t *testing.T) {
+	setupAccountTables(t)
+
+	response, _ := AccountServiceHandler(context.Background(), deleteAccountRequest(uuid.New().String(), "N:user:owner"))
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func TestDeleteAccountHandlerNotOwner(t *testing.T) {
+	client := setupAccountTables(t)
+	accountUuid := insertAccount(t, client, "N:user:owner")
+
+	response, _ := AccountServiceHandler(context.Background(), deleteAccountRequest(accountUuid, "N:user:someone-else"))
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+	assertAccountExists(t, client, accountUuid, true)
+}
+
+func TestDeleteAccountHandlerDependentNodes(t *testing.T) {
+	client := setupAccountTables(t)
+	accountUuid := insertAccount(t, client, "N:user:owner")
+	_, err := client.PutItem(context.Background(), &dynamodb.PutItemInput{
+		TableName: aws.String("handler-compute-nodes"),
+		Item: map[string]types.AttributeValue{
+			"uuid":        &types.AttributeValueMemberS{Value: uuid.New().String()},
+			"accountUuid": &types.AttributeValueMemberS{Value: accountUuid},
+		},
+	})
+	require.NoError(t, err)
+
+	response, _ := AccountServiceHandler(context.Background(), deleteAccountRequest(accountUuid, "N:user:owner"))
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+
+	var apiError APIError
+	assert.NoError(t, json.Unmarshal([]byte(response.Body), &apiError))
+	assert.Equal(t, "ACCOUNT_HAS_NODES", apiError.Code)
+	assertAccountExists(t, client, accountUuid, true)
+}
+
+func TestDeleteAccountHandlerOwner(t *testing.T) {
+	client := setupAccountTables(t)
+	accountUuid := insertAccount(t, client, "N:user:owner")
+
+	response, _ := AccountServiceHandler(context.Background(), deleteAccountRequest(accountUuid, "N:user:owner"))
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+	assertAccountExists(t, client, accountUuid, false)
+}
+
+func TestGetAccountsByAccountIdOwnerInOtherOrganization(t *testing.T) {
+	client := setupAccountTables(t)
+	accountUuid := insertAccountInOrganization(t, client, "N:user:owner", "N:organization:a", "123456789012")
+
+	response, _ := AccountServiceHandler(context.Background(), getAccountsRequest(
+		map[string]string{"accountId": "123456789012"},
+		callerClaims("N:user:owner", "N:organization:b", false)))
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, []string{accountUuid}, accountUuids(t, response.Body))
+}
+
+func TestGetAccountsByAccountIdSameOrganizationNonOwner(t *testing.T) {
+	client := setupAccountTables(t)
+	accountUuid := insertAccountInOrganization(t, client, "N:user:colleague", "N:organization:a", "123456789012")
+
+	response, _ := AccountServiceHandler(context.Background(), getAccountsRequest(
+		map[string]string{"accountId": "123456789012"},
+		callerClaims("N:user:caller", "N:organization:a", false)))
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, []string{accountUuid}, accountUuids(t, response.Body))
+}
+
+func TestGetAccountsByAccountIdOtherOrganizationNonOwner(t *testing.T) {
+	client := setupAccountTables(t)
+	insertAccountInOrganization(t, client, "N:user:stranger", "N:organization:a", "123456789012")
+
+	response, _ := AccountServiceHandler(context.Background(), getAccountsRequest(
+		map[string]string{"accountId": "123456789012"},
+		callerClaims("N:user:caller", "N:organization:b", false)))
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func TestGetAccountsByAccountIdSuperAdmin(t *testing.T) {
+	client := setupAccountTables(t)
+	first := insertAccountInOrganization(t, client, "N:user:someone", "N:organization:a", "123456789012")
+	second := insertAccountInOrganization(t, client, "N:user:someone-else", "N:organization:b", "123456789012")
+
+	response, _ := AccountServiceHandler(context.Background(), getAccountsRequest(
+		map[string]string{"accountId": "123456789012"},
+		callerClaims("N:user:admin", "N:organization:c", true)))
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.ElementsMatch(t, []string{first, second}, accountUuids(t, response.Body))
+}
+
+func TestGetAccountsMergesOrganizationAndUserAccounts(t *testing.T) {
+	client := setupAccountTables(t)
+	ownInOrganization := insertAccountInOrganization(t, client, "N:user:caller", "N:organization:a", "111111111111")
+	ownInOtherOrganization := insertAccountInOrganization(t, client, "N:user:caller", "N:organization:b", "222222222222")
+	colleagueInOrganization := insertAccountInOrganization(t, client, "N:user:colleague", "N:organization:a", "333333333333")
+
+	response, _ := AccountServiceHandler(context.Background(), getAccountsRequest(
+		nil, callerClaims("N:user:caller", "N:organization:a", false)))
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	var accounts []models.AccountWithOwnership
+	require.NoError(t, json.Unmarshal([]byte(response.Body), &accounts))
+	isOwner := map[string]bool{}
+	for _, a := range accounts {
+		isOwner[a.Uuid] = a.IsOwner
+	}
+	assert.Len(t, accounts, 3)
+	assert.Equal(t, map[string]bool{
+		ownInOrganization:       true,
+		ownInOtherOrganization:  true,
+		colleagueInOrganization: false,
+	}, isOwner)
+}
@@ -3,43 +3,55 @@ package handler
 import (
 	"context"
 	"encoding/json"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 
 	"github.com/aws/aws-lambda-go/events"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/google/uuid"
+	"github.com/pennsieve/account-service/service/logging"
 	"github.com/pennsieve/account-service/service/models"
 	"github.com/pennsieve/account-service/service/store_dynamodb"
+	"github.com/pennsieve/account-service/service/utils"
 	"github.com/pennsieve/pennsieve-go-core/pkg/authorizer"
 )
 
 func PostAccountsHandler(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
 	handlerName := "PostAccountsHandler"
+	handlerLogger := logging.NewHandlerLogger(ctx, handlerName)
 	var account models.Account
 	if err := json.Unmarshal([]byte(request.Body), &account); err != nil {
-		log.Println(err.Error())
+		handlerLogger.Error("error unmarshaling request body", slog.Any("error", err))
 		return events.APIGatewayV2HTTPResponse{
 			StatusCode: http.StatusInternalServerError,
-			Body:       handlerError(handlerName, ErrUnmarshaling),
+			Body:       handlerError(ctx, handlerName, ErrUnmarshaling),
+		}, nil
+	}
+	if err := validateRequiredFields(
+		requiredField{"accountId", account.AccountId},
+		requiredField{"accountType", account.AccountType}); err != nil {
+		handlerLogger.Error("invalid request body", slog.Any("error", err))
+		return events.APIGatewayV2HTTPResponse{
+			StatusCode: http.StatusBadRequest,
+			Body:       handlerError(ctx, handlerName, err),
 		}, nil
 	}
 
 	claims := authorizer.ParseClaims(request.RequestContext.Authorizer.Lambda)
 	organizationId := claims.OrgClaim.NodeId
 	userId := claims.UserClaim.NodeId
+	handlerLogger = handlerLogger.With(
+		slog.String("organizationId", organizationId),
+		slog.String("userId", userId))
 
-	cfg, err := config.LoadDefaultConfig(ctx)
+	dynamoDBClient, err := utils.NewDynamoClient(ctx)
 	if err != nil {
-		log.Println(err.Error())
+		handlerLogger.Error("error creating DynamoDB client", slog.Any("error", err))
 		return events.APIGatewayV2HTTPResponse{
 			StatusCode: http.StatusInternalServerError,
-			Body:       handlerError(handlerName, ErrConfig),
+			Body:       handlerError(ctx, handlerName, ErrConfig),
 		}, nil
 	}
-	dynamoDBClient := dynamodb.NewFromConfig(cfg)
 	accountsTable := os.Getenv("ACCOUNTS_TABLE")
 	accountsStore := store_dynamodb.NewAccountDatabaseStore(dynamoDBClient, accountsTable)
 
@@ -48,16 +60,16 @@ func PostAccountsHandler(ctx context.Context, request events.APIGatewayV2HTTPReq
 	queryParams["accountId"] = account.AccountId
 	accounts, err := accountsStore.Get(ctx, organizationId, queryParams)
 	if err != nil {
-		log.Println(err.Error())
+		handlerLogger.Error("error getting accounts", slog.Any("error", err))
 		return events.APIGatewayV2HTTPResponse{
 			StatusCode: http.StatusInternalServerError,
-			Body:       handlerError(handlerName, ErrConfig),
+			Body:       handlerError(ctx, handlerName, ErrDynamoDB),
 		}, nil
 	}
 	if len(accounts) > 0 {
 		return events.APIGatewayV2HTTPResponse{
 			StatusCode: http.StatusUnprocessableEntity,
-			Body:       handlerError(handlerName, ErrRecordAlreadyExists),
+			Body:       handlerError(ctx, handlerName, ErrRecordAlreadyExists),
 		}, nil
 	}
 
@@ -76,10 +88,10 @@ func PostAccountsHandler(ctx context.Context, request events.APIGatewayV2HTTPReq
 	}
 	err = accountsStore.Insert(ctx, store_account)
 	if err != nil {
-		log.Println(err.Error())
+		handlerLogger.Error("error inserting account", slog.Any("error", err))
 		return events.APIGatewayV2HTTPResponse{
 			StatusCode: http.StatusInternalServerError,
-			Body:       handlerError(handlerName, ErrDynamoDB),
+			Body:       handlerError(ctx, handlerName, ErrDynamoDB),
 		}, nil
 	}
 
@@ -87,10 +99,10 @@ func PostAccountsHandler(ctx context.Context, request events.APIGatewayV2HTTPReq
 		Uuid: registeredAccountId,
 	})
 	if err != nil {
-		log.Println(err.Error())
+		handlerLogger.Error("error marshaling account response", slog.Any("error", err))
 		return events.APIGatewayV2HTTPResponse{
 			StatusCode: http.StatusInternalServerError,
-			Body:       handlerError(handlerName, ErrMarshaling),
+			Body:       handlerError(ctx, handlerName, ErrMarshaling),
 		}, nil
 	}
 
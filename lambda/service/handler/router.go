@@ -2,10 +2,11 @@ package handler
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"net/http"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/pennsieve/account-service/service/logging"
 	"github.com/pennsieve/account-service/service/utils"
 )
 
@@ -15,18 +16,21 @@ type RouterHandlerFunc func(context.Context, events.APIGatewayV2HTTPRequest) (ev
 type Router interface {
 	POST(string, RouterHandlerFunc)
 	GET(string, RouterHandlerFunc)
+	DELETE(string, RouterHandlerFunc)
 	Start(context.Context, events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error)
 }
 
 type LambdaRouter struct {
-	getRoutes  map[string]RouterHandlerFunc
-	postRoutes map[string]RouterHandlerFunc
+	getRoutes    map[string]RouterHandlerFunc
+	postRoutes   map[string]RouterHandlerFunc
+	deleteRoutes map[string]RouterHandlerFunc
 }
 
 func NewLambdaRouter() Router {
 	return &LambdaRouter{
 		make(map[string]RouterHandlerFunc),
 		make(map[string]RouterHandlerFunc),
+		make(map[string]RouterHandlerFunc),
 	}
 }
 
@@ -38,9 +42,23 @@ func (r *LambdaRouter) GET(routeKey string, handler RouterHandlerFunc) {
 	r.getRoutes[routeKey] = handler
 }
 
+func (r *LambdaRouter) DELETE(routeKey string, handler RouterHandlerFunc) {
+	r.deleteRoutes[routeKey] = handler
+}
+
 func (r *LambdaRouter) Start(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
-	log.Println(request)
+	requestLogger := logging.FromContext(ctx)
 	routeKey := utils.ExtractRoute(request.RouteKey)
+	if err := validateBodySize(request.Body); err != nil {
+		requestLogger.Error(err.Error(), slog.String("routeKey", request.RouteKey))
+		return events.APIGatewayV2HTTPResponse{
+			StatusCode: http.StatusRequestEntityTooLarge,
+			Body:       apiErrorBody(ctx, err.Error(), err),
+		}, nil
+	}
+	requestLogger.Debug("routing request",
+		slog.String("routeKey", request.RouteKey),
+		slog.String("method", request.RequestContext.HTTP.Method))
 
 	switch request.RequestContext.HTTP.Method {
 	case http.MethodPost:
@@ -48,28 +66,35 @@ func (r *LambdaRouter) Start(ctx context.Context, request events.APIGatewayV2HTT
 		if ok {
 			return f(ctx, request)
 		} else {
-			return handleError()
+			return handleError(ctx)
 		}
 	case http.MethodGet:
 		f, ok := r.getRoutes[routeKey]
 		if ok {
 			return f(ctx, request)
 		} else {
-			return handleError()
+			return handleError(ctx)
+		}
+	case http.MethodDelete:
+		f, ok := r.deleteRoutes[routeKey]
+		if ok {
+			return f(ctx, request)
+		} else {
+			return handleError(ctx)
 		}
 	default:
-		log.Println(ErrUnsupportedPath.Error())
+		requestLogger.Error(ErrUnsupportedPath.Error(), slog.String("method", request.RequestContext.HTTP.Method))
 		return events.APIGatewayV2HTTPResponse{
 			StatusCode: http.StatusUnprocessableEntity,
-			Body:       ErrUnsupportedPath.Error(),
+			Body:       apiErrorBody(ctx, ErrUnsupportedPath.Error(), ErrUnsupportedPath),
 		}, nil
 	}
 }
 
-func handleError() (events.APIGatewayV2HTTPResponse, error) {
-	log.Println(ErrUnsupportedRoute.Error())
+func handleError(ctx context.Context) (events.APIGatewayV2HTTPResponse, error) {
+	logging.FromContext(ctx).Error(ErrUnsupportedRoute.Error())
 	return events.APIGatewayV2HTTPResponse{
 		StatusCode: http.StatusNotFound,
-		Body:       ErrUnsupportedRoute.Error(),
+		Body:       apiErrorBody(ctx, ErrUnsupportedRoute.Error(), ErrUnsupportedRoute),
 	}, nil
 }
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// DefaultMaxBodySize is the request body limit in bytes used when MAX_BODY_SIZE is unset or invalid
+const DefaultMaxBodySize = 256 * 1024
+
+func maxBodySize() int {
+	if size, err := strconv.Atoi(os.Getenv("MAX_BODY_SIZE")); err == nil && size > 0 {
+		return size
+	}
+	return DefaultMaxBodySize
+}
+
+// validateBodySize rejects request bodies larger than the configured maximum
+func validateBodySize(body string) error {
+	if limit := maxBodySize(); len(body) > limit {
+		return fmt.Errorf("%w: %d bytes exceeds limit of %d bytes", ErrPayloadTooLarge, len(body), limit)
+	}
+	return nil
+}
+
+// requiredField pairs a request field name with the value supplied for it
+type requiredField struct {
+	name  string
+	value string
+}
+
+// validateRequiredFields returns an error naming the first field with an empty value.
+// Fields are checked in the order given so the error is deterministic.
+func validateRequiredFields(fields ...requiredField) error {
+	for _, f := range fields {
+		if f.value == "" {
+			return fmt.Errorf("%w: %s", ErrMissingRequiredField, f.name)
+		}
+	}
+	return nil
+}
@@ -1,8 +1,11 @@
 package logging
 
 import (
+	"context"
 	"log/slog"
 	"os"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
 )
 
 // Level is the current log level of Default. To change the level at runtime, for example to DEBUG, call Level.Set(slog.LevelDebug)
@@ -35,3 +38,35 @@ func configureLogging() {
 	slog.Info("default log level set", slog.String("logging.Level", Level.String()))
 	Default = slog.Default()
 }
+
+type requestIdKey struct{}
+
+// WithRequestId returns a copy of ctx carrying the API Gateway request id
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+// RequestId returns the API Gateway request id carried by ctx, or an empty string if there is none
+func RequestId(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdKey{}).(string)
+	return requestId
+}
+
+// FromContext returns a logger derived from Default that tags every record with the
+// API Gateway request id and the Lambda invocation id carried by ctx, when present.
+func FromContext(ctx context.Context) *slog.Logger {
+	logger := Default
+	if requestId := RequestId(ctx); requestId != "" {
+		logger = logger.With(slog.String("requestId", requestId))
+	}
+	if lc, ok := lambdacontext.FromContext(ctx); ok {
+		logger = logger.With(slog.String("awsRequestId", lc.AwsRequestID))
+	}
+	return logger
+}
+
+// NewHandlerLogger returns a logger derived from FromContext that also tags every record with the given handler name.
+// Callers add request-specific fields such as userId or organizationId with With once they are known.
+func NewHandlerLogger(ctx context.Context, handlerName string) *slog.Logger {
+	return FromContext(ctx).With(slog.String("handlerName", handlerName))
+}
@@ -1,6 +1,9 @@
 package logging
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"github.com/stretchr/testify/require"
 	"log/slog"
 	"testing"
@@ -32,3 +35,23 @@ func assertLogLevelStrict(t *testing.T, logger *slog.Logger, expectedLevel slog.
 	require.True(t, logger.Enabled(nil, expectedLevel+1))
 	require.False(t, logger.Enabled(nil, expectedLevel-1))
 }
+
+func TestNewHandlerLogger(t *testing.T) {
+	var buf bytes.Buffer
+	original := Default
+	Default = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: Level}))
+	t.Cleanup(func() { Default = original })
+
+	NewHandlerLogger(WithRequestId(context.Background(), "some-request-id"), "SomeHandler").Info("test message", slog.String("userId", "N:user:1"))
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	require.Equal(t, "SomeHandler", record["handlerName"])
+	require.Equal(t, "N:user:1", record["userId"])
+	require.Equal(t, "some-request-id", record["requestId"])
+	require.Equal(t, "test message", record["msg"])
+}
+
+func TestRequestIdMissing(t *testing.T) {
+	require.Equal(t, "", RequestId(context.Background()))
+}
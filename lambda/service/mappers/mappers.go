@@ -5,11 +5,21 @@ import (
 	"github.com/pennsieve/account-service/service/store_dynamodb"
 )
 
-func DynamoDBAccountToJsonAccount(dynamoAccounts []store_dynamodb.Account) []models.Account {
-	accounts := []models.Account{}
+// DynamoDBAccountToJsonAccount maps stored accounts to their JSON form, flagging those registered by userId
+func DynamoDBAccountToJsonAccount(dynamoAccounts []store_dynamodb.Account, userId string) []models.AccountWithOwnership {
+	accounts := []models.AccountWithOwnership{}
 
 	for _, a := range dynamoAccounts {
-		accounts = append(accounts, models.Account{
+		accounts = append(accounts, DynamoDBAccountToAccountWithOwnership(a, userId))
+	}
+
+	return accounts
+}
+
+// DynamoDBAccountToAccountWithOwnership maps a stored account to its JSON form, flagging it if registered by userId
+func DynamoDBAccountToAccountWithOwnership(a store_dynamodb.Account, userId string) models.AccountWithOwnership {
+	return models.AccountWithOwnership{
+		Account: models.Account{
 			Uuid:           a.Uuid,
 			AccountId:      a.AccountId,
 			AccountType:    a.AccountType,
@@ -17,8 +27,7 @@ func DynamoDBAccountToJsonAccount(dynamoAccounts []store_dynamodb.Account) []mod
 			ExternalId:     a.ExternalId,
 			OrganizationId: a.OrganizationId,
 			UserId:         a.UserId,
-		})
+		},
+		IsOwner: a.UserId == userId,
 	}
-
-	return accounts
 }
@@ -10,6 +10,12 @@ type Account struct {
 	UserId         string `json:"userId"`
 }
 
+// AccountWithOwnership is the response shape for account reads; IsOwner reports whether the caller registered the account
+type AccountWithOwnership struct {
+	Account
+	IsOwner bool `json:"isOwner"`
+}
+
 type AccountResponse struct {
 	Uuid string `json:"uuid"`
 }
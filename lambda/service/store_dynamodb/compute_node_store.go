@@ -0,0 +1,58 @@
+package store_dynamodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// ComputeNodeStore is a read-only view of the compute-node service's nodes table,
+// used to guard account deletion against orphaning nodes
+type ComputeNodeStore interface {
+	HasNodesForAccount(context.Context, string) (bool, error)
+}
+
+type ComputeNodeDatabaseStore struct {
+	DB        *dynamodb.Client
+	TableName string
+}
+
+func NewComputeNodeDatabaseStore(db *dynamodb.Client, tableName string) ComputeNodeStore {
+	return &ComputeNodeDatabaseStore{db, tableName}
+}
+
+// HasNodesForAccount reports whether any compute node references the given account uuid.
+// It relies on the compute-node service storing the backing account on each node as accountUuid
+// (models.DynamoDBNode.AccountUuid, dynamodbav:"accountUuid"); if that attribute is renamed this guard stops matching.
+// The nodes table has no accountUuid index, so this is a filtered scan that stops at the first matching page.
+func (r *ComputeNodeDatabaseStore) HasNodesForAccount(ctx context.Context, accountUuid string) (bool, error) {
+	filt := expression.Name("accountUuid").Equal(expression.Value(accountUuid))
+	proj := expression.NamesList(expression.Name("uuid"))
+	expr, err := expression.NewBuilder().WithFilter(filt).WithProjection(proj).Build()
+	if err != nil {
+		return false, fmt.Errorf("error building expression: %w", err)
+	}
+
+	// a filtered scan can return empty pages before a match, so keep paging until one is found
+	paginator := dynamodb.NewScanPaginator(r.DB, &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(r.TableName),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return false, fmt.Errorf("error scanning compute nodes: %w", err)
+		}
+		if page.Count > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
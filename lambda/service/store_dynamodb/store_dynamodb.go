@@ -10,10 +10,19 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 )
 
+// AccountIdIndex is the name of the accounts table GSI keyed on accountId
+const AccountIdIndex = "accountId-index"
+
+// UserIdIndex is the name of the accounts table GSI keyed on userId
+const UserIdIndex = "userId-index"
+
 type DynamoDBStore interface {
 	Insert(context.Context, Account) error
 	GetById(context.Context, string) (Account, error)
 	Get(context.Context, string, map[string]string) ([]Account, error)
+	Delete(context.Context, string) error
+	GetByAccountId(context.Context, string) ([]Account, error)
+	GetByUser(context.Context, string) ([]Account, error)
 }
 
 type AccountDatabaseStore struct {
@@ -93,3 +102,73 @@ func (r *AccountDatabaseStore) Get(ctx context.Context, organizationId string, p
 
 	return accounts, nil
 }
+
+func (r *AccountDatabaseStore) Delete(ctx context.Context, uuid string) error {
+	account := Account{Uuid: uuid}
+	_, err := r.DB.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		Key: account.GetKey(), TableName: aws.String(r.TableName),
+	})
+	if err != nil {
+		return fmt.Errorf("error deleting account: %w", err)
+	}
+
+	return nil
+}
+
+// GetByAccountId returns every registration of the given cloud account id, across organizations
+func (r *AccountDatabaseStore) GetByAccountId(ctx context.Context, accountId string) ([]Account, error) {
+	accounts := []Account{}
+
+	keyCond := expression.Key("accountId").Equal(expression.Value(accountId))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return accounts, fmt.Errorf("error building expression: %w", err)
+	}
+
+	response, err := r.DB.Query(ctx, &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		IndexName:                 aws.String(AccountIdIndex),
+		TableName:                 aws.String(r.TableName),
+	})
+	if err != nil {
+		return accounts, fmt.Errorf("error querying accounts by accountId: %w", err)
+	}
+
+	err = attributevalue.UnmarshalListOfMaps(response.Items, &accounts)
+	if err != nil {
+		return accounts, fmt.Errorf("error unmarshaling accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// GetByUser returns every account registered by the given user, across organizations
+func (r *AccountDatabaseStore) GetByUser(ctx context.Context, userId string) ([]Account, error) {
+	accounts := []Account{}
+
+	keyCond := expression.Key("userId").Equal(expression.Value(userId))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return accounts, fmt.Errorf("error building expression: %w", err)
+	}
+
+	response, err := r.DB.Query(ctx, &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		IndexName:                 aws.String(UserIdIndex),
+		TableName:                 aws.String(r.TableName),
+	})
+	if err != nil {
+		return accounts, fmt.Errorf("error querying accounts by userId: %w", err)
+	}
+
+	err = attributevalue.UnmarshalListOfMaps(response.Items, &accounts)
+	if err != nil {
+		return accounts, fmt.Errorf("error unmarshaling accounts: %w", err)
+	}
+
+	return accounts, nil
+}
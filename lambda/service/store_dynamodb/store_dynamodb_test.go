@@ -2,51 +2,22 @@ package store_dynamodb_test
 
 import (
 	"context"
-	"log"
-	"os"
 	"testing"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/google/uuid"
 	"github.com/pennsieve/account-service/service/store_dynamodb"
+	"github.com/pennsieve/account-service/service/testutils"
 )
 
-func getEnv(key, fallback string) string {
-	if value, ok := os.LookupEnv(key); ok {
-		return value
-	}
-	return fallback
-}
-
-func getClient() *dynamodb.Client {
-	testDBUri := getEnv("DYNAMODB_URL", "http://localhost:8000")
-
-	cfg, err := config.LoadDefaultConfig(context.TODO(),
-		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("dummy", "dummy_secret", "1234")),
-		config.WithEndpointResolverWithOptions(aws.EndpointResolverWithOptionsFunc(
-			func(service, region string, options ...interface{}) (aws.Endpoint, error) {
-				return aws.Endpoint{URL: testDBUri}, nil
-			})),
-	)
-	if err != nil {
-		panic(err)
-	}
-
-	svc := dynamodb.NewFromConfig(cfg)
-	return svc
-}
-
 func TestInsertAndGetById(t *testing.T) {
 	tableName := "accounts"
-	dynamoDBClient := getClient()
+	dynamoDBClient := testutils.GetClient(t)
 
 	// create table
-	_, err := CreateAccountsTable(dynamoDBClient, tableName)
+	_, err := testutils.CreateAccountsTable(dynamoDBClient, tableName)
 	if err != nil {
 		t.Fatalf("err creating table")
 	}
@@ -76,7 +47,7 @@ func TestInsertAndGetById(t *testing.T) {
 	}
 
 	// delete table
-	err = DeleteTable(dynamoDBClient, tableName)
+	err = testutils.DeleteTable(dynamoDBClient, tableName)
 	if err != nil {
 		t.Fatalf("err creating table")
 	}
@@ -85,10 +56,10 @@ func TestInsertAndGetById(t *testing.T) {
 
 func TestInsertAndGet(t *testing.T) {
 	tableName := "accounts"
-	dynamoDBClient := getClient()
+	dynamoDBClient := testutils.GetClient(t)
 
 	// create table
-	_, err := CreateAccountsTable(dynamoDBClient, tableName)
+	_, err := testutils.CreateAccountsTable(dynamoDBClient, tableName)
 	if err != nil {
 		t.Fatalf("err creating table")
 	}
@@ -133,46 +104,205 @@ func TestInsertAndGet(t *testing.T) {
 	}
 
 	// delete table
-	err = DeleteTable(dynamoDBClient, tableName)
+	err = testutils.DeleteTable(dynamoDBClient, tableName)
 	if err != nil {
 		t.Fatalf("err creating table")
 	}
 
 }
 
-func CreateAccountsTable(dynamoDBClient *dynamodb.Client, tableName string) (*types.TableDescription, error) {
-	var tableDesc *types.TableDescription
-	table, err := dynamoDBClient.CreateTable(context.TODO(), &dynamodb.CreateTableInput{
-		AttributeDefinitions: []types.AttributeDefinition{{
-			AttributeName: aws.String("uuid"),
-			AttributeType: types.ScalarAttributeTypeS,
-		}},
-		KeySchema: []types.KeySchemaElement{{
-			AttributeName: aws.String("uuid"),
-			KeyType:       types.KeyTypeHash,
-		}},
-		TableName:   aws.String(tableName),
-		BillingMode: "PAY_PER_REQUEST",
-	})
+func TestInsertAndDelete(t *testing.T) {
+	tableName := "accounts"
+	dynamoDBClient := testutils.GetClient(t)
+
+	// create table
+	_, err := testutils.CreateAccountsTable(dynamoDBClient, tableName)
+	if err != nil {
+		t.Fatalf("err creating table")
+	}
+	dynamo_store := store_dynamodb.NewAccountDatabaseStore(dynamoDBClient, tableName)
+	registeredAccountId := uuid.New().String()
+	store_account := store_dynamodb.Account{
+		Uuid:           registeredAccountId,
+		UserId:         "SomeId",
+		OrganizationId: "SomeOrgId",
+		AccountId:      "SomeAccountId",
+		AccountType:    "aws",
+		RoleName:       "SomeRoleName",
+		ExternalId:     "SomeExternalId",
+	}
+	err = dynamo_store.Insert(context.Background(), store_account)
+	if err != nil {
+		t.Errorf("error inserting item into table")
+	}
+
+	err = dynamo_store.Delete(context.Background(), registeredAccountId)
+	if err != nil {
+		t.Errorf("error deleting item from table")
+	}
+
+	accountItem, err := dynamo_store.GetById(context.Background(), registeredAccountId)
+	if err != nil {
+		t.Errorf("error getting item from table")
+	}
+	if (store_dynamodb.Account{}) != accountItem {
+		t.Errorf("expected account %s to be deleted", registeredAccountId)
+	}
+
+	// delete table
+	err = testutils.DeleteTable(dynamoDBClient, tableName)
+	if err != nil {
+		t.Fatalf("err creating table")
+	}
+
+}
+
+func TestInsertAndGetByAccountId(t *testing.T) {
+	tableName := "accounts"
+	dynamoDBClient := testutils.GetClient(t)
+
+	// create table
+	_, err := testutils.CreateAccountsTable(dynamoDBClient, tableName)
+	if err != nil {
+		t.Fatalf("err creating table")
+	}
+	dynamo_store := store_dynamodb.NewAccountDatabaseStore(dynamoDBClient, tableName)
+
+	accountId := "123456789012"
+	organizationIds := []string{"SomeOrgId", "SomeOtherOrgId"}
+	for _, o := range organizationIds {
+		store_account := store_dynamodb.Account{
+			Uuid:           uuid.New().String(),
+			UserId:         "SomeId",
+			OrganizationId: o,
+			AccountId:      accountId,
+			AccountType:    "aws",
+			RoleName:       "SomeRoleName",
+			ExternalId:     "SomeExternalId",
+		}
+		err = dynamo_store.Insert(context.Background(), store_account)
+		if err != nil {
+			t.Errorf("error inserting item into table")
+		}
+	}
+
+	accounts, err := dynamo_store.GetByAccountId(context.Background(), accountId)
 	if err != nil {
-		log.Printf("couldn't create table %v. Here's why: %v\n", tableName, err)
-	} else {
-		waiter := dynamodb.NewTableExistsWaiter(dynamoDBClient)
-		err = waiter.Wait(context.TODO(), &dynamodb.DescribeTableInput{
-			TableName: aws.String(tableName)}, 5*time.Minute)
+		t.Errorf("error getting items")
+	}
+	if len(accounts) != len(organizationIds) {
+		t.Errorf("expected %v accounts, not %v", len(organizationIds), len(accounts))
+	}
+
+	accounts, err = dynamo_store.GetByAccountId(context.Background(), "SomeUnknownAccountId")
+	if err != nil {
+		t.Errorf("error getting items")
+	}
+	if len(accounts) != 0 {
+		t.Errorf("expected %v accounts, not %v", 0, len(accounts))
+	}
+
+	// delete table
+	err = testutils.DeleteTable(dynamoDBClient, tableName)
+	if err != nil {
+		t.Fatalf("err creating table")
+	}
+
+}
+
+func TestInsertAndGetByUser(t *testing.T) {
+	tableName := "accounts"
+	dynamoDBClient := testutils.GetClient(t)
+
+	// create table
+	_, err := testutils.CreateAccountsTable(dynamoDBClient, tableName)
+	if err != nil {
+		t.Fatalf("err creating table")
+	}
+	dynamo_store := store_dynamodb.NewAccountDatabaseStore(dynamoDBClient, tableName)
+
+	userIds := []string{"SomeId", "SomeId", "SomeOtherId"}
+	organizationIds := []string{"SomeOrgId", "SomeOtherOrgId", "SomeOrgId"}
+	for i := range userIds {
+		store_account := store_dynamodb.Account{
+			Uuid:           uuid.New().String(),
+			UserId:         userIds[i],
+			OrganizationId: organizationIds[i],
+			AccountId:      uuid.New().String(),
+			AccountType:    "aws",
+			RoleName:       "SomeRoleName",
+			ExternalId:     "SomeExternalId",
+		}
+		err = dynamo_store.Insert(context.Background(), store_account)
 		if err != nil {
-			log.Printf("wait for table exists failed. Here's why: %v\n", err)
+			t.Errorf("error inserting item into table")
+		}
+	}
+
+	accounts, err := dynamo_store.GetByUser(context.Background(), "SomeId")
+	if err != nil {
+		t.Errorf("error getting items")
+	}
+	if len(accounts) != 2 {
+		t.Errorf("expected %v accounts, not %v", 2, len(accounts))
+	}
+	for _, a := range accounts {
+		if a.UserId != "SomeId" {
+			t.Errorf("expected userId %s, got %s", "SomeId", a.UserId)
 		}
-		tableDesc = table.TableDescription
 	}
-	return tableDesc, err
+
+	// delete table
+	err = testutils.DeleteTable(dynamoDBClient, tableName)
+	if err != nil {
+		t.Fatalf("err creating table")
+	}
+
 }
 
-func DeleteTable(dynamoDBClient *dynamodb.Client, tableName string) error {
-	_, err := dynamoDBClient.DeleteTable(context.TODO(), &dynamodb.DeleteTableInput{
-		TableName: aws.String(tableName)})
+func TestHasNodesForAccount(t *testing.T) {
+	tableName := "compute-nodes"
+	dynamoDBClient := testutils.GetClient(t)
+
+	// create table
+	_, err := testutils.CreateComputeNodesTable(dynamoDBClient, tableName)
 	if err != nil {
-		log.Printf("couldn't delete table %v. Here's why: %v\n", tableName, err)
+		t.Fatalf("err creating table")
 	}
-	return err
+	nodes_store := store_dynamodb.NewComputeNodeDatabaseStore(dynamoDBClient, tableName)
+
+	accountUuid := uuid.New().String()
+	_, err = dynamoDBClient.PutItem(context.Background(), &dynamodb.PutItemInput{
+		TableName: aws.String(tableName),
+		Item: map[string]types.AttributeValue{
+			"uuid":        &types.AttributeValueMemberS{Value: uuid.New().String()},
+			"accountUuid": &types.AttributeValueMemberS{Value: accountUuid},
+		},
+	})
+	if err != nil {
+		t.Errorf("error inserting item into table")
+	}
+
+	hasNodes, err := nodes_store.HasNodesForAccount(context.Background(), accountUuid)
+	if err != nil {
+		t.Errorf("error checking for nodes")
+	}
+	if !hasNodes {
+		t.Errorf("expected nodes for account %s", accountUuid)
+	}
+
+	hasNodes, err = nodes_store.HasNodesForAccount(context.Background(), uuid.New().String())
+	if err != nil {
+		t.Errorf("error checking for nodes")
+	}
+	if hasNodes {
+		t.Errorf("expected no nodes for an unreferenced account")
+	}
+
+	// delete table
+	err = testutils.DeleteTable(dynamoDBClient, tableName)
+	if err != nil {
+		t.Fatalf("err deleting table")
+	}
+
 }
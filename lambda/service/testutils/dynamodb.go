@@ -0,0 +1,118 @@
+package testutils
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/pennsieve/account-service/service/store_dynamodb"
+	"github.com/pennsieve/account-service/service/utils"
+)
+
+// GetClient points utils.NewDynamoClient at DynamoDB-local, defaulting DYNAMODB_URL when it is not already set
+func GetClient(t *testing.T) *dynamodb.Client {
+	t.Setenv("ENV", "TEST")
+	if _, ok := os.LookupEnv("DYNAMODB_URL"); !ok {
+		t.Setenv("DYNAMODB_URL", "http://localhost:8000")
+	}
+
+	svc, err := utils.NewDynamoClient(context.Background())
+	if err != nil {
+		t.Fatalf("error creating DynamoDB client: %v", err)
+	}
+	return svc
+}
+
+// CreateAccountsTable creates an accounts table with the same keys and GSIs as terraform/dynamodb.tf
+func CreateAccountsTable(dynamoDBClient *dynamodb.Client, tableName string) (*types.TableDescription, error) {
+	return createTable(dynamoDBClient, &dynamodb.CreateTableInput{
+		AttributeDefinitions: []types.AttributeDefinition{
+			{
+				AttributeName: aws.String("uuid"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("accountId"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("userId"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+		},
+		KeySchema: []types.KeySchemaElement{{
+			AttributeName: aws.String("uuid"),
+			KeyType:       types.KeyTypeHash,
+		}},
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{{
+			IndexName: aws.String(store_dynamodb.AccountIdIndex),
+			KeySchema: []types.KeySchemaElement{{
+				AttributeName: aws.String("accountId"),
+				KeyType:       types.KeyTypeHash,
+			}},
+			Projection: &types.Projection{
+				ProjectionType: types.ProjectionTypeAll,
+			},
+		}, {
+			IndexName: aws.String(store_dynamodb.UserIdIndex),
+			KeySchema: []types.KeySchemaElement{{
+				AttributeName: aws.String("userId"),
+				KeyType:       types.KeyTypeHash,
+			}},
+			Projection: &types.Projection{
+				ProjectionType: types.ProjectionTypeAll,
+			},
+		}},
+		TableName:   aws.String(tableName),
+		BillingMode: "PAY_PER_REQUEST",
+	})
+}
+
+// CreateComputeNodesTable creates a minimal stand-in for the compute-node service's nodes table, keyed on uuid
+func CreateComputeNodesTable(dynamoDBClient *dynamodb.Client, tableName string) (*types.TableDescription, error) {
+	return createTable(dynamoDBClient, &dynamodb.CreateTableInput{
+		AttributeDefinitions: []types.AttributeDefinition{{
+			AttributeName: aws.String("uuid"),
+			AttributeType: types.ScalarAttributeTypeS,
+		}},
+		KeySchema: []types.KeySchemaElement{{
+			AttributeName: aws.String("uuid"),
+			KeyType:       types.KeyTypeHash,
+		}},
+		TableName:   aws.String(tableName),
+		BillingMode: "PAY_PER_REQUEST",
+	})
+}
+
+func createTable(dynamoDBClient *dynamodb.Client, input *dynamodb.CreateTableInput) (*types.TableDescription, error) {
+	var tableDesc *types.TableDescription
+	tableName := aws.ToString(input.TableName)
+	table, err := dynamoDBClient.CreateTable(context.TODO(), input)
+	if err != nil {
+		log.Printf("couldn't create table %v. Here's why: %v\n", tableName, err)
+	} else {
+		waiter := dynamodb.NewTableExistsWaiter(dynamoDBClient)
+		err = waiter.Wait(context.TODO(), &dynamodb.DescribeTableInput{
+			TableName: aws.String(tableName)}, 5*time.Minute)
+		if err != nil {
+			log.Printf("wait for table exists failed. Here's why: %v\n", err)
+		}
+		tableDesc = table.TableDescription
+	}
+	return tableDesc, err
+}
+
+// DeleteTable deletes the named table
+func DeleteTable(dynamoDBClient *dynamodb.Client, tableName string) error {
+	_, err := dynamoDBClient.DeleteTable(context.TODO(), &dynamodb.DeleteTableInput{
+		TableName: aws.String(tableName)})
+	if err != nil {
+		log.Printf("couldn't delete table %v. Here's why: %v\n", tableName, err)
+	}
+	return err
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"context"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// NewDynamoClient returns a DynamoDB client for the current environment.
+// When ENV is TEST or DOCKER and DYNAMODB_URL is set, the client targets that local endpoint with static test credentials.
+func NewDynamoClient(ctx context.Context) (*dynamodb.Client, error) {
+	env := os.Getenv("ENV")
+	dynamoDBUrl := os.Getenv("DYNAMODB_URL")
+
+	if (env == "TEST" || env == "DOCKER") && dynamoDBUrl != "" {
+		cfg, err := config.LoadDefaultConfig(ctx,
+			config.WithRegion("us-east-1"),
+			config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("dummy", "dummy_secret", "1234")),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return dynamodb.NewFromConfig(cfg, func(o *dynamodb.Options) {
+			o.BaseEndpoint = aws.String(dynamoDBUrl)
+		}), nil
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dynamodb.NewFromConfig(cfg), nil
+}
@@ -1,6 +1,7 @@
 package utils_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/aws/aws-lambda-go/events"
@@ -17,3 +18,30 @@ func TestExtractRouteKey(t *testing.T) {
 		t.Errorf("expected %s, got %s", expected, got)
 	}
 }
+
+func TestNewDynamoClientLocalEndpoint(t *testing.T) {
+	t.Setenv("ENV", "TEST")
+	t.Setenv("DYNAMODB_URL", "http://localhost:8000")
+
+	client, err := utils.NewDynamoClient(context.Background())
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	endpoint := client.Options().BaseEndpoint
+	if endpoint == nil || *endpoint != "http://localhost:8000" {
+		t.Errorf("expected endpoint %s, got %v", "http://localhost:8000", endpoint)
+	}
+}
+
+func TestNewDynamoClientDefaultEndpoint(t *testing.T) {
+	t.Setenv("ENV", "dev")
+	t.Setenv("DYNAMODB_URL", "http://localhost:8000")
+
+	client, err := utils.NewDynamoClient(context.Background())
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	if endpoint := client.Options().BaseEndpoint; endpoint != nil {
+		t.Errorf("expected default endpoint, got %s", *endpoint)
+	}
+}
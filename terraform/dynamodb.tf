@@ -7,6 +7,28 @@ resource "aws_dynamodb_table" "accounts_table" {
     name = "uuid"
     type = "S"
   }
+
+  attribute {
+    name = "accountId"
+    type = "S"
+  }
+
+  attribute {
+    name = "userId"
+    type = "S"
+  }
+
+  global_secondary_index {
+    name            = "accountId-index"
+    hash_key        = "accountId"
+    projection_type = "ALL"
+  }
+
+  global_secondary_index {
+    name            = "userId-index"
+    hash_key        = "userId"
+    projection_type = "ALL"
+  }
   
   ttl {
     attribute_name = "TimeToExist"
@@ -68,6 +68,7 @@ data "aws_iam_policy_document" "service_iam_policy_document" {
       "dynamodb:Scan",
       "dynamodb:BatchWriteItem",
       "dynamodb:PutItem",
+      "dynamodb:DeleteItem",
       "dynamodb:UpdateItem"
     ]
 
@@ -78,4 +79,18 @@ data "aws_iam_policy_document" "service_iam_policy_document" {
 
   }
 
+  statement {
+    sid = "LambdaReadComputeNodesTable"
+    effect = "Allow"
+
+    actions = [
+      "dynamodb:Scan"
+    ]
+
+    resources = [
+      local.compute_nodes_table_arn
+    ]
+
+  }
+
 }
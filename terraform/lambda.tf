@@ -21,6 +21,7 @@ resource "aws_lambda_function" "service_lambda" {
       PENNSIEVE_DOMAIN = data.terraform_remote_state.account.outputs.domain_name,
       REGION           = var.aws_region,
       ACCOUNTS_TABLE = aws_dynamodb_table.accounts_table.name
+      COMPUTE_NODES_TABLE = local.compute_nodes_table_name
     }
   }
 }
@@ -23,4 +23,9 @@ locals {
     aws_region       = data.aws_region.current_region.name
     environment_name = var.environment_name
   }
+
+  # Compute nodes table owned by the compute-node service, read to guard account deletion.
+  # Built from the shared environment naming convention so plans don't depend on the table existing.
+  compute_nodes_table_name = "${var.environment_name}-compute-resource-nodes-table-${data.terraform_remote_state.region.outputs.aws_region_shortname}"
+  compute_nodes_table_arn  = "arn:aws:dynamodb:${var.aws_region}:${data.aws_caller_identity.current.account_id}:table/${local.compute_nodes_table_name}"
 }
\ No newline at end of file